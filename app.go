@@ -5,6 +5,10 @@ import (
 	"embed"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/wangle201210/wachat/backend"
 	"github.com/wangle201210/wachat/backend/config"
@@ -22,6 +26,7 @@ type App struct {
 	ctx           context.Context
 	chatAPI       *backend.API
 	binaryManager *service.BinaryManager
+	cleanupOnce   sync.Once
 }
 
 // NewApp creates new App
@@ -37,10 +42,44 @@ func NewApp(cfg *config.Config) *App {
 		log.Printf("Binary manager: %v", err)
 	}
 
-	return &App{
+	app := &App{
 		chatAPI:       api,
 		binaryManager: binaryManager,
 	}
+
+	app.forwardSignals()
+
+	return app
+}
+
+// forwardSignals watches for SIGINT/SIGTERM delivered to the wachat process,
+// forwards a graceful shutdown to all managed binaries, then exits the
+// process; the Wails OnShutdown hook does not run in that case.
+func (a *App) forwardSignals() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		sig := <-sigCh
+		log.Printf("Received %v, shutting down managed binaries", sig)
+		a.cleanupBinaries()
+		// Notify intercepts the signal's default disposition, so we must
+		// exit ourselves once cleanup is done.
+		os.Exit(0)
+	}()
+}
+
+// cleanupBinaries stops all managed binaries. It is safe to call more than
+// once; only the first call does any work.
+func (a *App) cleanupBinaries() {
+	a.cleanupOnce.Do(func() {
+		if a.binaryManager == nil {
+			return
+		}
+		if err := a.binaryManager.Cleanup(); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	})
 }
 
 // startup is called when app starts
@@ -50,18 +89,25 @@ func (a *App) startup(ctx context.Context) {
 
 	// Start all embedded binaries
 	if a.binaryManager != nil {
+		lifecycle := a.binaryManager.SubscribeLifecycle()
+		go func() {
+			for evt := range lifecycle {
+				runtime.EventsEmit(ctx, "binary:lifecycle", evt)
+			}
+		}()
+
 		if err := a.binaryManager.StartAll(ctx); err != nil {
 			log.Printf("Warning: Failed to start binaries: %v", err)
 		}
+		a.binaryManager.StreamLogs(ctx, func(name string, line service.LogLine) {
+			runtime.EventsEmit(ctx, "binary:log:"+name, line)
+		})
 	}
 }
 
 // shutdown is called when app stops
 func (a *App) shutdown(ctx context.Context) {
-	// Cleanup managed binaries
-	if a.binaryManager != nil {
-		a.binaryManager.Cleanup()
-	}
+	a.cleanupBinaries()
 }
 
 // CreateConversation creates new conversation
@@ -84,6 +130,32 @@ func (a *App) DeleteConversation(id string) error {
 	return a.chatAPI.DeleteConversation(id)
 }
 
+// BinaryStatuses returns the current supervision state of every managed binary.
+func (a *App) BinaryStatuses() []service.BinaryStatus {
+	if a.binaryManager == nil {
+		return nil
+	}
+	return a.binaryManager.Status()
+}
+
+// VerifyBinaries re-checks every managed binary's on-disk file against its
+// expected hash and reports which ones, if any, failed verification.
+func (a *App) VerifyBinaries() []service.VerifyResult {
+	if a.binaryManager == nil {
+		return nil
+	}
+	return a.binaryManager.Verify()
+}
+
+// TailBinaryLog returns the last n captured log lines for a managed binary,
+// for an initial render of its console panel before live events arrive.
+func (a *App) TailBinaryLog(name string, n int) []service.LogLine {
+	if a.binaryManager == nil {
+		return nil
+	}
+	return a.binaryManager.Tail(name, n)
+}
+
 // SendMessageStream streams AI response using eino
 func (a *App) SendMessageStream(conversationID, content string) error {
 	// Create event callback that emits Wails runtime events
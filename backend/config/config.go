@@ -6,8 +6,13 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/wangle201210/wachat/backend/service"
 )
 
 // Config holds all configuration
@@ -25,10 +30,64 @@ type AIConfig struct {
 
 // BinariesConfig holds binary manager configuration
 type BinariesConfig struct {
-	Enabled      bool     `mapstructure:"enabled"`
-	UseEmbedded  bool     `mapstructure:"use_embedded"` // true: use embedded, false: use local bin/ directory
-	BinPath      string   `mapstructure:"bin_path"`     // local bin directory path (default: "./bin")
-	StartupOrder []string `mapstructure:"startup_order"`
+	Enabled      bool                    `mapstructure:"enabled"`
+	UseEmbedded  bool                    `mapstructure:"use_embedded"` // true: use embedded, false: use local bin/ directory
+	BinPath      string                  `mapstructure:"bin_path"`     // local bin directory path (default: "./bin")
+	StartupOrder []string                `mapstructure:"startup_order"`
+	PerBinary    map[string]BinaryConfig `mapstructure:"per_binary"` // per-binary settings keyed by name
+	LogDir       string                  `mapstructure:"log_dir"`    // directory for rotated log files (default: "<cache dir>/logs")
+	LogMaxBytes  int                     `mapstructure:"log_max_bytes"`
+	LogMaxFiles  int                     `mapstructure:"log_max_files"`
+	LogMaxSize   int64                   `mapstructure:"log_max_size"`
+}
+
+// BinaryConfig holds settings for a single managed binary.
+type BinaryConfig struct {
+	Restart        *RestartPolicyConfig `mapstructure:"restart"`
+	ShutdownSignal string               `mapstructure:"shutdown_signal"` // e.g. "SIGTERM", "SIGINT"; defaults to SIGTERM
+	ShutdownGrace  time.Duration        `mapstructure:"shutdown_grace"`  // how long to wait before SIGKILL; defaults to 10s
+	Checksum       string               `mapstructure:"checksum"`        // expected sha256 of the binary, local mode only
+	DependsOn      []string             `mapstructure:"depends_on"`      // names of binaries that must be ready first
+	Readiness      *ReadinessConfig     `mapstructure:"readiness"`
+	Sandbox        *SandboxConfig       `mapstructure:"sandbox"`
+}
+
+// SandboxConfig declares optional Linux resource limits and isolation for a
+// single managed binary. It has no effect on non-Linux platforms.
+type SandboxConfig struct {
+	MemoryMax  string   `mapstructure:"memory_max"` // e.g. "256Mi", "1Gi"
+	CPUMax     string   `mapstructure:"cpu_max"`    // cgroup v2 cpu.max syntax, e.g. "50000 100000"
+	PidsMax    int64    `mapstructure:"pids_max"`
+	NoNewPrivs bool     `mapstructure:"no_new_privs"`
+	PrivateTmp bool     `mapstructure:"private_tmp"`
+	DropCaps   []string `mapstructure:"drop_caps"`
+	Mounts     []struct {
+		Src string `mapstructure:"src"`
+		Dst string `mapstructure:"dst"`
+		RO  bool   `mapstructure:"ro"`
+	} `mapstructure:"mounts"`
+}
+
+// ReadinessConfig declares how to probe a binary for readiness once it has
+// started. Type is one of "tcp", "http" or "exec".
+type ReadinessConfig struct {
+	Type             string        `mapstructure:"type"`
+	Target           string        `mapstructure:"target"` // host:port (tcp), URL (http), command (exec)
+	Args             []string      `mapstructure:"args"`   // exec only
+	InitialDelay     time.Duration `mapstructure:"initial_delay"`
+	Interval         time.Duration `mapstructure:"interval"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+}
+
+// RestartPolicyConfig declares how a supervised binary should be restarted
+// after it exits. Mode is one of "on-failure", "always" or "never".
+type RestartPolicyConfig struct {
+	Mode        string        `mapstructure:"mode"`
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	Delay       time.Duration `mapstructure:"delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+	ResetAfter  time.Duration `mapstructure:"reset_after"`
 }
 
 // IsEnabled returns whether binary manager is enabled
@@ -57,6 +116,166 @@ func (c *BinariesConfig) GetStartupOrder() []string {
 	return c.StartupOrder
 }
 
+// GetRestartPolicy returns the restart policy configured for the named
+// binary, defaulting to "never" restart when none is configured.
+func (c *BinariesConfig) GetRestartPolicy(name string) service.RestartPolicy {
+	if c == nil || c.PerBinary == nil {
+		return service.RestartPolicy{Mode: service.RestartNever}
+	}
+	bc, ok := c.PerBinary[name]
+	if !ok || bc.Restart == nil {
+		return service.RestartPolicy{Mode: service.RestartNever}
+	}
+
+	r := bc.Restart
+	return service.RestartPolicy{
+		Mode:        r.Mode,
+		MaxAttempts: r.MaxAttempts,
+		Delay:       r.Delay,
+		MaxDelay:    r.MaxDelay,
+		ResetAfter:  r.ResetAfter,
+	}
+}
+
+// GetShutdownPolicy returns the shutdown policy configured for the named
+// binary, defaulting to SIGTERM with a 10s grace period when none is
+// configured or the signal name is invalid.
+func (c *BinariesConfig) GetShutdownPolicy(name string) service.ShutdownPolicy {
+	grace := 10 * time.Second
+	signalName := ""
+
+	if c != nil && c.PerBinary != nil {
+		if bc, ok := c.PerBinary[name]; ok {
+			signalName = bc.ShutdownSignal
+			if bc.ShutdownGrace > 0 {
+				grace = bc.ShutdownGrace
+			}
+		}
+	}
+
+	sig, err := service.ParseSignal(signalName)
+	if err != nil {
+		log.Printf("Invalid shutdown_signal %q for %s, using SIGTERM: %v", signalName, name, err)
+		sig, _ = service.ParseSignal("")
+	}
+
+	return service.ShutdownPolicy{Signal: sig, Grace: grace}
+}
+
+// GetChecksum returns the expected sha256 checksum configured for the named
+// binary, or "" if none is configured.
+func (c *BinariesConfig) GetChecksum(name string) string {
+	if c == nil || c.PerBinary == nil {
+		return ""
+	}
+	return c.PerBinary[name].Checksum
+}
+
+// GetLogSettings returns the log capture settings shared by every managed
+// binary.
+func (c *BinariesConfig) GetLogSettings() service.LogSettings {
+	if c == nil {
+		return service.LogSettings{}
+	}
+	return service.LogSettings{
+		MaxBytes: c.LogMaxBytes,
+		Dir:      c.LogDir,
+		MaxFiles: c.LogMaxFiles,
+		MaxSize:  c.LogMaxSize,
+	}
+}
+
+// GetDependsOn returns the names of binaries that must be ready before the
+// named binary is started.
+func (c *BinariesConfig) GetDependsOn(name string) []string {
+	if c == nil || c.PerBinary == nil {
+		return nil
+	}
+	return c.PerBinary[name].DependsOn
+}
+
+// GetReadinessProbe returns the readiness probe configured for the named
+// binary. A zero-value probe means the binary is considered ready as soon
+// as it starts.
+func (c *BinariesConfig) GetReadinessProbe(name string) service.ReadinessProbe {
+	if c == nil || c.PerBinary == nil {
+		return service.ReadinessProbe{}
+	}
+	bc, ok := c.PerBinary[name]
+	if !ok || bc.Readiness == nil {
+		return service.ReadinessProbe{}
+	}
+
+	r := bc.Readiness
+	return service.ReadinessProbe{
+		Type:             service.ProbeType(r.Type),
+		Target:           r.Target,
+		Args:             r.Args,
+		InitialDelay:     r.InitialDelay,
+		Interval:         r.Interval,
+		Timeout:          r.Timeout,
+		FailureThreshold: r.FailureThreshold,
+	}
+}
+
+// GetSandboxPolicy returns the sandbox policy configured for the named
+// binary, or a disabled SandboxSpec if none is configured.
+func (c *BinariesConfig) GetSandboxPolicy(name string) service.SandboxSpec {
+	if c == nil || c.PerBinary == nil {
+		return service.SandboxSpec{}
+	}
+	bc, ok := c.PerBinary[name]
+	if !ok || bc.Sandbox == nil {
+		return service.SandboxSpec{}
+	}
+
+	s := bc.Sandbox
+	memMax, err := parseMemorySize(s.MemoryMax)
+	if err != nil {
+		log.Printf("Invalid sandbox.memory_max %q for %s, ignoring: %v", s.MemoryMax, name, err)
+	}
+
+	mounts := make([]service.SandboxMount, 0, len(s.Mounts))
+	for _, m := range s.Mounts {
+		mounts = append(mounts, service.SandboxMount{Src: m.Src, Dst: m.Dst, RO: m.RO})
+	}
+
+	return service.SandboxSpec{
+		Enabled:    true,
+		MemoryMax:  memMax,
+		CPUMax:     s.CPUMax,
+		PidsMax:    s.PidsMax,
+		NoNewPrivs: s.NoNewPrivs,
+		PrivateTmp: s.PrivateTmp,
+		DropCaps:   s.DropCaps,
+		Mounts:     mounts,
+	}
+}
+
+// parseMemorySize parses a cgroup-style size such as "256Mi" or "1Gi" into
+// bytes. A plain number is interpreted as bytes. An empty string returns 0
+// without limiting memory.
+func parseMemorySize(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	units := map[string]int64{
+		"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30,
+		"K": 1000, "M": 1000 * 1000, "G": 1000 * 1000 * 1000,
+	}
+	for suffix, mult := range units {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.ParseInt(strings.TrimSuffix(s, suffix), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q: %w", s, err)
+			}
+			return n * mult, nil
+		}
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
 var globalConfig *Config
 
 // findProjectRoot tries to find project root by looking for go.mod
@@ -9,16 +9,97 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 )
 
+// BinaryState describes the supervision state of a managed binary.
+type BinaryState string
+
+const (
+	StateRunning BinaryState = "running"
+	StateBackoff BinaryState = "backoff"
+	StateFailed  BinaryState = "failed"
+	StateStopped BinaryState = "stopped"
+)
+
+// BinaryStatus is a point-in-time snapshot of a managed binary's supervision state.
+type BinaryStatus struct {
+	Name            string
+	State           BinaryState
+	Pid             int
+	LastExitCode    int
+	RestartCount    int
+	NextRestartTime time.Time
+	Sandbox         SandboxStatus
+}
+
+// managedBinary tracks the runtime and supervision state of a single binary.
+type managedBinary struct {
+	name           string
+	executablePath string
+	restart        RestartPolicy
+	shutdown       ShutdownPolicy
+	sandbox        SandboxSpec
+	logs           *LogCapture
+
+	mu              sync.Mutex
+	cmd             *exec.Cmd
+	exitCh          chan struct{}
+	exitStatusCh    <-chan ExitStatus
+	stopping        bool
+	state           BinaryState
+	lastExitCode    int
+	restartCount    int
+	nextRestartTime time.Time
+	startedAt       time.Time
+	sandboxStatus   SandboxStatus
+}
+
+func (m *managedBinary) status() BinaryStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pid := 0
+	if m.cmd != nil && m.cmd.Process != nil {
+		pid = m.cmd.Process.Pid
+	}
+	return BinaryStatus{
+		Name:            m.name,
+		State:           m.state,
+		Pid:             pid,
+		LastExitCode:    m.lastExitCode,
+		RestartCount:    m.restartCount,
+		NextRestartTime: m.nextRestartTime,
+		Sandbox:         m.sandboxStatus,
+	}
+}
+
 // BinaryManager manages binaries lifecycle (embedded or local)
 type BinaryManager struct {
 	useEmbedded bool
 	binaries    embed.FS
 	binPath     string
-	processes   []*exec.Cmd
 	execOrder   []string
 	cacheDir    string
+
+	mu             sync.Mutex
+	managed        map[string]*managedBinary
+	restarts       map[string]RestartPolicy
+	shutdowns      map[string]ShutdownPolicy
+	embeddedHashes map[string]string // name -> sha256, embedded mode only
+	checksums      map[string]string // name -> expected sha256, local mode only
+	logSettings    LogSettings
+	dependsOn      map[string][]string
+	readiness      map[string]ReadinessProbe
+	sandboxes      map[string]SandboxSpec
+	startOrder     []string // execOrder topologically sorted by dependsOn, set by StartAll
+
+	lifecycleMu   sync.Mutex
+	lifecycleSubs map[chan LifecycleEvent]struct{}
 }
 
 // BinariesConfig interface for config dependency
@@ -27,6 +108,13 @@ type BinariesConfig interface {
 	IsUseEmbedded() bool
 	GetBinPath() string
 	GetStartupOrder() []string
+	GetRestartPolicy(name string) RestartPolicy
+	GetShutdownPolicy(name string) ShutdownPolicy
+	GetChecksum(name string) string
+	GetLogSettings() LogSettings
+	GetDependsOn(name string) []string
+	GetReadinessProbe(name string) ReadinessProbe
+	GetSandboxPolicy(name string) SandboxSpec
 }
 
 // NewBinaryManagerFromConfig creates a binary manager from config
@@ -47,10 +135,32 @@ func NewBinaryManagerFromConfig(cfg BinariesConfig, binaries embed.FS) (*BinaryM
 
 	useEmbedded := cfg.IsUseEmbedded()
 
+	restarts := make(map[string]RestartPolicy, len(startupOrder))
+	shutdowns := make(map[string]ShutdownPolicy, len(startupOrder))
+	checksums := make(map[string]string, len(startupOrder))
+	dependsOn := make(map[string][]string, len(startupOrder))
+	readiness := make(map[string]ReadinessProbe, len(startupOrder))
+	sandboxes := make(map[string]SandboxSpec, len(startupOrder))
+	for _, name := range startupOrder {
+		restarts[name] = cfg.GetRestartPolicy(name)
+		shutdowns[name] = cfg.GetShutdownPolicy(name)
+		checksums[name] = cfg.GetChecksum(name)
+		dependsOn[name] = cfg.GetDependsOn(name)
+		readiness[name] = cfg.GetReadinessProbe(name)
+		sandboxes[name] = cfg.GetSandboxPolicy(name)
+	}
+
 	bm, err := NewBinaryManager(useEmbedded, binaries, binPath, startupOrder)
 	if err != nil {
 		return nil, err
 	}
+	bm.restarts = restarts
+	bm.shutdowns = shutdowns
+	bm.checksums = checksums
+	bm.logSettings = cfg.GetLogSettings()
+	bm.dependsOn = dependsOn
+	bm.readiness = readiness
+	bm.sandboxes = sandboxes
 
 	// Log initialization mode
 	if useEmbedded {
@@ -67,6 +177,7 @@ func NewBinaryManagerFromConfig(cfg BinariesConfig, binaries embed.FS) (*BinaryM
 // If useEmbedded is false, binaries will be loaded from binPath directory
 func NewBinaryManager(useEmbedded bool, binaries embed.FS, binPath string, execOrder []string) (*BinaryManager, error) {
 	var cacheDir string
+	var embeddedHashes map[string]string
 
 	if useEmbedded {
 		// Get app cache directory for embedded mode
@@ -80,6 +191,11 @@ func NewBinaryManager(useEmbedded bool, binaries embed.FS, binPath string, execO
 		if err := os.MkdirAll(cacheDir, 0755); err != nil {
 			return nil, fmt.Errorf("failed to create app cache directory: %w", err)
 		}
+
+		embeddedHashes, err = hashEmbeddedBinaries(binaries)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		// Use local bin directory
 		if !filepath.IsAbs(binPath) {
@@ -94,111 +210,631 @@ func NewBinaryManager(useEmbedded bool, binaries embed.FS, binPath string, execO
 		log.Printf("Using local bin directory: %s", binPath)
 	}
 
+	startReaper()
+
 	return &BinaryManager{
-		useEmbedded: useEmbedded,
-		binaries:    binaries,
-		binPath:     binPath,
-		processes:   make([]*exec.Cmd, 0),
-		execOrder:   execOrder,
-		cacheDir:    cacheDir,
+		useEmbedded:    useEmbedded,
+		binaries:       binaries,
+		binPath:        binPath,
+		execOrder:      execOrder,
+		cacheDir:       cacheDir,
+		managed:        make(map[string]*managedBinary),
+		restarts:       make(map[string]RestartPolicy),
+		shutdowns:      make(map[string]ShutdownPolicy),
+		embeddedHashes: embeddedHashes,
+		checksums:      make(map[string]string),
+		dependsOn:      make(map[string][]string),
+		readiness:      make(map[string]ReadinessProbe),
+		sandboxes:      make(map[string]SandboxSpec),
+		lifecycleSubs:  make(map[chan LifecycleEvent]struct{}),
 	}, nil
 }
 
-// StartAll starts all binaries in the specified order
+// StartAll starts every binary in dependency order: independent binaries
+// start in parallel, and a binary's start is blocked until every binary in
+// its depends_on has passed its readiness probe (or the probe's deadline
+// elapses).
 func (bm *BinaryManager) StartAll(ctx context.Context) error {
-	successCount := 0
-	for _, binaryName := range bm.execOrder {
-		if err := bm.startBinary(ctx, binaryName); err != nil {
-			log.Printf("Failed to start %s: %v", binaryName, err)
-			// Continue with next binary instead of stopping
-			continue
-		}
-		successCount++
+	order, err := topoSort(bm.execOrder, bm.dependsOn)
+	if err != nil {
+		return fmt.Errorf("invalid startup dependency graph: %w", err)
+	}
+
+	bm.mu.Lock()
+	bm.startOrder = order
+	bm.mu.Unlock()
+
+	ready := make(map[string]chan struct{}, len(order))
+	for _, name := range order {
+		ready[name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	var successCount int32
+	for _, name := range order {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer close(ready[name])
+
+			for _, dep := range bm.dependsOn[name] {
+				depReady, ok := ready[dep]
+				if !ok {
+					continue
+				}
+				select {
+				case <-depReady:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			bm.emitLifecycle(name, PhaseStarting, nil)
+
+			if err := bm.startBinary(ctx, name); err != nil {
+				log.Printf("Failed to start %s: %v", name, err)
+				bm.emitLifecycle(name, PhaseUnhealthy, err)
+				return
+			}
+
+			if err := bm.waitReady(ctx, name, bm.readiness[name]); err != nil {
+				log.Printf("%s failed readiness probe: %v", name, err)
+				bm.emitLifecycle(name, PhaseUnhealthy, err)
+				return
+			}
+
+			bm.emitLifecycle(name, PhaseReady, nil)
+			atomic.AddInt32(&successCount, 1)
+		}(name)
 	}
+	wg.Wait()
 
 	if successCount == 0 {
 		return fmt.Errorf("failed to start any binaries")
 	}
 
-	log.Printf("Started %d/%d binaries successfully", successCount, len(bm.execOrder))
+	log.Printf("Started %d/%d binaries successfully", successCount, len(order))
 	return nil
 }
 
-// startBinary extracts (if embedded) and starts a single binary
-func (bm *BinaryManager) startBinary(ctx context.Context, name string) error {
-	var executablePath string
+// extract resolves the on-disk executable path for name, extracting it from
+// embed.FS first if running in embedded mode. In embedded mode, extraction
+// is skipped entirely when the on-disk file's hash already matches the
+// embedded binary, and writes are atomic so a crash can't leave a
+// half-written executable behind. In local mode, the binary is verified
+// against an optional configured checksum before it's allowed to start.
+func (bm *BinaryManager) extract(name string) (string, error) {
+	executablePath := filepath.Join(bm.cacheDir, name)
 
 	if bm.useEmbedded {
-		// Embedded mode: extract from embed.FS
+		expectedHash, ok := bm.embeddedHashes[name]
+		if !ok {
+			return "", fmt.Errorf("embedded binary %s not found", name)
+		}
+
+		if onDiskHash, err := sha256File(executablePath); err == nil && onDiskHash == expectedHash {
+			log.Printf("%s is already extracted and up to date, skipping extraction", name)
+			return executablePath, nil
+		}
+
 		binaryPath := filepath.Join("bin", name)
 		data, err := fs.ReadFile(bm.binaries, binaryPath)
 		if err != nil {
-			return fmt.Errorf("failed to read embedded binary %s: %w", name, err)
+			return "", fmt.Errorf("failed to read embedded binary %s: %w", name, err)
 		}
 
-		// Extract to cache directory
-		executablePath = filepath.Join(bm.cacheDir, name)
-		if err := os.WriteFile(executablePath, data, 0755); err != nil {
-			return fmt.Errorf("failed to write binary %s: %w", name, err)
+		if err := writeFileAtomic(executablePath, data, 0755); err != nil {
+			return "", fmt.Errorf("failed to write binary %s: %w", name, err)
 		}
 		log.Printf("Extracted %s to %s", name, executablePath)
-	} else {
-		// Local mode: use binary from local directory
-		executablePath = filepath.Join(bm.cacheDir, name)
 
-		// Check if binary exists
-		if _, err := os.Stat(executablePath); err != nil {
-			return fmt.Errorf("binary %s not found at %s: %w", name, executablePath, err)
+		m := readManifest(bm.cacheDir)
+		m[name] = manifestEntry{SHA256: expectedHash, Size: int64(len(data)), Mode: 0755}
+		if err := writeManifest(bm.cacheDir, m); err != nil {
+			log.Printf("Warning: failed to write manifest for %s: %v", name, err)
 		}
 
-		// Ensure executable permission
-		if err := os.Chmod(executablePath, 0755); err != nil {
-			log.Printf("Warning: failed to chmod %s: %v", name, err)
+		return executablePath, nil
+	}
+
+	if _, err := os.Stat(executablePath); err != nil {
+		return "", fmt.Errorf("binary %s not found at %s: %w", name, executablePath, err)
+	}
+	if err := os.Chmod(executablePath, 0755); err != nil {
+		log.Printf("Warning: failed to chmod %s: %v", name, err)
+	}
+
+	if expected := bm.checksums[name]; expected != "" {
+		actual, err := sha256File(executablePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", name, err)
+		}
+		if !strings.EqualFold(actual, expected) {
+			return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, expected, actual)
 		}
+	}
+
+	log.Printf("Using local binary: %s", executablePath)
+	return executablePath, nil
+}
+
+// startBinary extracts (if embedded) and starts a single binary, then hands
+// it off to the supervisor which keeps it running according to the
+// binary's restart policy.
+func (bm *BinaryManager) startBinary(ctx context.Context, name string) error {
+	executablePath, err := bm.extract(name)
+	if err != nil {
+		return err
+	}
+
+	policy, ok := bm.restarts[name]
+	if !ok {
+		policy = defaultRestartPolicy()
+	}
+	shutdown, ok := bm.shutdowns[name]
+	if !ok {
+		shutdown = defaultShutdownPolicy()
+	}
+
+	logSettings := bm.logSettings
+	if logSettings.Dir == "" {
+		logSettings.Dir = filepath.Join(bm.cacheDir, "logs")
+	}
+	logs, err := newLogCapture(name, logSettings)
+	if err != nil {
+		log.Printf("Warning: log file disabled for %s: %v", name, err)
+		logs, _ = newLogCapture(name, LogSettings{MaxBytes: logSettings.MaxBytes})
+	}
+
+	mb := &managedBinary{
+		name:           name,
+		executablePath: executablePath,
+		restart:        policy,
+		shutdown:       shutdown,
+		sandbox:        bm.sandboxes[name],
+		logs:           logs,
+	}
 
-		log.Printf("Using local binary: %s", executablePath)
+	bm.mu.Lock()
+	bm.managed[name] = mb
+	bm.mu.Unlock()
+
+	if err := bm.launch(ctx, mb); err != nil {
+		return err
 	}
 
-	// Run binary in background
-	cmd := exec.CommandContext(ctx, executablePath)
+	go bm.supervise(ctx, mb)
+
+	return nil
+}
+
+// launch starts mb's executable and records the resulting *exec.Cmd. The
+// binary is re-run from its already-extracted path, so restarts never
+// re-read embed.FS. It deliberately uses exec.Command rather than
+// exec.CommandContext(ctx, ...): the latter kills the process the instant
+// ctx is cancelled, which races (and can pre-empt) shutdownOne's own
+// signal/grace/kill escalation when the app's context is cancelled at
+// shutdown. Process termination is driven solely through shutdownOne.
+func (bm *BinaryManager) launch(ctx context.Context, mb *managedBinary) error {
+	cmd := exec.Command(mb.executablePath)
 	cmd.Dir = bm.cacheDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+
+	var stdoutPipe, stderrPipe *os.File
+	if mb.logs != nil {
+		var err error
+		stdoutPipe, err = mb.logs.Pipe("stdout", os.Stdout)
+		if err != nil {
+			return fmt.Errorf("failed to open stdout pipe for %s: %w", mb.name, err)
+		}
+		stderrPipe, err = mb.logs.Pipe("stderr", os.Stderr)
+		if err != nil {
+			stdoutPipe.Close()
+			return fmt.Errorf("failed to open stderr pipe for %s: %w", mb.name, err)
+		}
+		cmd.Stdout = stdoutPipe
+		cmd.Stderr = stderrPipe
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+	setProcessGroup(cmd)
+
+	sandboxStatus, err := applySandbox(cmd, mb.name, mb.sandbox)
+	if err != nil {
+		return fmt.Errorf("failed to sandbox %s: %w", mb.name, err)
+	}
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start %s: %w", name, err)
+		if stdoutPipe != nil {
+			stdoutPipe.Close()
+		}
+		if stderrPipe != nil {
+			stderrPipe.Close()
+		}
+		return fmt.Errorf("failed to start %s: %w", mb.name, err)
 	}
 
-	log.Printf("%s started successfully (PID: %d)", name, cmd.Process.Pid)
+	// Register interest in this pid's exit immediately, in the same
+	// goroutine as Start, with no scheduling gap. If this were instead done
+	// later in supervise's own goroutine, a child that exits before that
+	// goroutine is scheduled would be reaped by the SIGCHLD handler with no
+	// handler registered yet, and the eventual registration would then wait
+	// forever on a pid that's already gone - hanging both supervision and
+	// graceful shutdown.
+	exitStatusCh := waitForExit(cmd)
 
-	// Save process reference
-	bm.processes = append(bm.processes, cmd)
+	// The child has its own copy of these fds now; close our end so the
+	// capture's read goroutines see EOF when the child exits, rather than
+	// staying open for wachat's own lifetime.
+	if stdoutPipe != nil {
+		stdoutPipe.Close()
+	}
+	if stderrPipe != nil {
+		stderrPipe.Close()
+	}
+
+	if sandboxStatus.Sandboxed {
+		if err := joinCgroup(mb.name, cmd.Process.Pid); err != nil {
+			log.Printf("Warning: failed to apply cgroup limits to %s: %v", mb.name, err)
+		}
+	}
 
-	// Wait for process in a goroutine
-	go func(processName string, process *exec.Cmd) {
-		if err := process.Wait(); err != nil {
-			log.Printf("%s exited with error: %v", processName, err)
+	log.Printf("%s started successfully (PID: %d)", mb.name, cmd.Process.Pid)
+
+	mb.mu.Lock()
+	mb.cmd = cmd
+	mb.exitCh = make(chan struct{})
+	mb.exitStatusCh = exitStatusCh
+	mb.state = StateRunning
+	mb.startedAt = time.Now()
+	mb.sandboxStatus = sandboxStatus
+	mb.mu.Unlock()
+
+	return nil
+}
+
+// supervise waits for mb's process to exit - via the package-level SIGCHLD
+// reaper on Unix, or a direct Wait() elsewhere - and restarts it according
+// to its restart policy, applying exponential backoff with full jitter
+// between attempts. It returns once the context is cancelled or the policy
+// gives up.
+func (bm *BinaryManager) supervise(ctx context.Context, mb *managedBinary) {
+	for {
+		mb.mu.Lock()
+		exitCh := mb.exitCh
+		exitStatusCh := mb.exitStatusCh
+		startedAt := mb.startedAt
+		mb.mu.Unlock()
+
+		result := <-exitStatusCh
+		close(exitCh)
+
+		exitCode := result.ExitCode
+		exitedCleanly := exitCode == 0 && !result.Signaled
+		if !exitedCleanly {
+			if result.Signaled {
+				log.Printf("%s exited via signal %s", mb.name, result.Signal)
+			} else {
+				log.Printf("%s exited with code %d", mb.name, exitCode)
+			}
+		} else {
+			log.Printf("%s exited successfully", mb.name)
+		}
+
+		if exitedCleanly {
+			bm.emitLifecycle(mb.name, PhaseExited, nil)
 		} else {
-			log.Printf("%s exited successfully", processName)
+			bm.emitLifecycle(mb.name, PhaseExited, fmt.Errorf("%s exited with code %d", mb.name, exitCode))
+		}
+
+		mb.mu.Lock()
+		stopping := mb.stopping
+		mb.mu.Unlock()
+		if ctx.Err() != nil || stopping {
+			mb.mu.Lock()
+			mb.state = StateStopped
+			mb.lastExitCode = exitCode
+			mb.mu.Unlock()
+			return
+		}
+
+		mb.mu.Lock()
+		if time.Since(startedAt) >= mb.restart.ResetAfter && mb.restart.ResetAfter > 0 {
+			mb.restartCount = 0
+		}
+		attempt := mb.restartCount + 1
+		restart := mb.restart.shouldRestart(exitedCleanly, attempt)
+		mb.lastExitCode = exitCode
+		mb.mu.Unlock()
+
+		if !restart {
+			mb.mu.Lock()
+			mb.state = StateFailed
+			mb.mu.Unlock()
+			log.Printf("%s will not be restarted (mode=%s, attempts=%d)", mb.name, mb.restart.Mode, attempt-1)
+			return
+		}
+
+		delay := mb.restart.backoff(attempt)
+		mb.mu.Lock()
+		mb.state = StateBackoff
+		mb.restartCount = attempt
+		mb.nextRestartTime = time.Now().Add(delay)
+		mb.mu.Unlock()
+
+		log.Printf("%s: restarting in %s (attempt %d)", mb.name, delay, attempt)
+
+		stopped := false
+		select {
+		case <-ctx.Done():
+			stopped = true
+		case <-time.After(delay):
+			mb.mu.Lock()
+			stopped = mb.stopping
+			mb.mu.Unlock()
+		}
+		if stopped {
+			mb.mu.Lock()
+			mb.state = StateStopped
+			mb.mu.Unlock()
+			return
 		}
-	}(name, cmd)
 
+		// shutdownOne may have flipped mb.stopping while we were sleeping above;
+		// recheck immediately before launching so a shutdownOne that lands in
+		// that window doesn't race a fresh process into existence.
+		mb.mu.Lock()
+		stopping = mb.stopping
+		mb.mu.Unlock()
+		if stopping {
+			mb.mu.Lock()
+			mb.state = StateStopped
+			mb.mu.Unlock()
+			return
+		}
+
+		if err := bm.launch(ctx, mb); err != nil {
+			log.Printf("%s: restart attempt %d failed: %v", mb.name, attempt, err)
+			mb.mu.Lock()
+			mb.state = StateFailed
+			mb.mu.Unlock()
+			return
+		}
+	}
+}
+
+// Status returns a snapshot of every managed binary's supervision state.
+func (bm *BinaryManager) Status() []BinaryStatus {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	statuses := make([]BinaryStatus, 0, len(bm.managed))
+	for _, name := range bm.execOrder {
+		mb, ok := bm.managed[name]
+		if !ok {
+			continue
+		}
+		statuses = append(statuses, mb.status())
+	}
+	return statuses
+}
+
+// Tail returns the last n captured log lines for the named binary, across
+// both stdout and stderr. It returns nil if the binary isn't managed.
+func (bm *BinaryManager) Tail(name string, n int) []LogLine {
+	bm.mu.Lock()
+	mb, ok := bm.managed[name]
+	bm.mu.Unlock()
+	if !ok || mb.logs == nil {
+		return nil
+	}
+	return mb.logs.tail(n)
+}
+
+// Subscribe returns a channel that receives every new log line captured for
+// the named binary from now on.
+func (bm *BinaryManager) Subscribe(name string) <-chan LogLine {
+	bm.mu.Lock()
+	mb, ok := bm.managed[name]
+	bm.mu.Unlock()
+	if !ok || mb.logs == nil {
+		ch := make(chan LogLine)
+		close(ch)
+		return ch
+	}
+	return mb.logs.subscribe()
+}
+
+// StreamLogs subscribes to every managed binary's log output and invokes
+// emit for each new line until ctx is cancelled. It's meant to be called
+// once, after StartAll, with emit wired to a UI event bridge such as Wails'
+// runtime.EventsEmit.
+func (bm *BinaryManager) StreamLogs(ctx context.Context, emit func(name string, line LogLine)) {
+	bm.mu.Lock()
+	names := make([]string, len(bm.execOrder))
+	copy(names, bm.execOrder)
+	bm.mu.Unlock()
+
+	for _, name := range names {
+		ch := bm.Subscribe(name)
+		go func(name string, ch <-chan LogLine) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case line, ok := <-ch:
+					if !ok {
+						return
+					}
+					emit(name, line)
+				}
+			}
+		}(name, ch)
+	}
+}
+
+// emitLifecycle notifies every lifecycle subscriber that name has entered phase.
+func (bm *BinaryManager) emitLifecycle(name string, phase LifecyclePhase, err error) {
+	evt := LifecycleEvent{Name: name, Phase: phase, Timestamp: time.Now(), Err: err}
+
+	bm.lifecycleMu.Lock()
+	subs := make([]chan LifecycleEvent, 0, len(bm.lifecycleSubs))
+	for ch := range bm.lifecycleSubs {
+		subs = append(subs, ch)
+	}
+	bm.lifecycleMu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- evt:
+		default: // drop if the subscriber isn't keeping up
+		}
+	}
+}
+
+// SubscribeLifecycle returns a channel that receives starting/ready/unhealthy
+// events for every managed binary as StartAll brings them up, and an exited
+// event each time a supervised process exits. Only events emitted after the
+// call are delivered.
+func (bm *BinaryManager) SubscribeLifecycle() <-chan LifecycleEvent {
+	ch := make(chan LifecycleEvent, 64)
+	bm.lifecycleMu.Lock()
+	bm.lifecycleSubs[ch] = struct{}{}
+	bm.lifecycleMu.Unlock()
+	return ch
+}
+
+// VerifyResult reports whether a single managed binary's on-disk file
+// matches its expected hash.
+type VerifyResult struct {
+	Name string
+	OK   bool
+	Err  error
+}
+
+// Verify re-hashes every managed binary's on-disk file and compares it
+// against the embedded hash (embedded mode) or configured checksum (local
+// mode), without starting or restarting anything. Binaries with no expected
+// hash configured are reported OK as long as their file can be read.
+func (bm *BinaryManager) Verify() []VerifyResult {
+	results := make([]VerifyResult, 0, len(bm.execOrder))
+	for _, name := range bm.execOrder {
+		err := bm.verifyOne(name)
+		results = append(results, VerifyResult{Name: name, OK: err == nil, Err: err})
+	}
+	return results
+}
+
+func (bm *BinaryManager) verifyOne(name string) error {
+	executablePath := filepath.Join(bm.cacheDir, name)
+	actual, err := sha256File(executablePath)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %w", name, err)
+	}
+
+	var expected string
+	if bm.useEmbedded {
+		expected = bm.embeddedHashes[name]
+	} else {
+		expected = bm.checksums[name]
+	}
+	if expected == "" {
+		return nil
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, expected, actual)
+	}
 	return nil
 }
 
-// GetProcessCount returns the number of running processes
+// GetProcessCount returns the number of binaries the manager has tried to
+// start, including any whose launch failed.
 func (bm *BinaryManager) GetProcessCount() int {
-	return len(bm.processes)
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	return len(bm.managed)
 }
 
-// Cleanup terminates all managed processes
-func (bm *BinaryManager) Cleanup() {
-	for i, cmd := range bm.processes {
-		if cmd.Process != nil {
-			log.Printf("Terminating process %d (PID: %d)", i, cmd.Process.Pid)
-			if err := cmd.Process.Kill(); err != nil {
-				log.Printf("Failed to kill process %d: %v", i, err)
-			}
+// Cleanup gracefully shuts down every managed process in reverse startup
+// order: it sends each binary's configured signal (SIGTERM by default),
+// waits up to its shutdown_grace, and escalates to SIGKILL if it is still
+// alive. It returns a summary error naming any process that had to be
+// force-killed, or nil if everything stopped cleanly.
+func (bm *BinaryManager) Cleanup() error {
+	bm.mu.Lock()
+	order := bm.startOrder
+	if order == nil {
+		order = bm.execOrder
+	}
+	names := make([]string, len(order))
+	copy(names, order)
+	bm.mu.Unlock()
+
+	var forced []string
+	for i := len(names) - 1; i >= 0; i-- {
+		name := names[i]
+		bm.mu.Lock()
+		mb, ok := bm.managed[name]
+		bm.mu.Unlock()
+		if !ok {
+			continue
+		}
+		if wasForced := bm.shutdownOne(mb); wasForced {
+			forced = append(forced, name)
+		}
+		if mb.logs != nil {
+			mb.logs.close()
+		}
+	}
+
+	if len(forced) > 0 {
+		return fmt.Errorf("force-killed after grace period: %s", strings.Join(forced, ", "))
+	}
+	return nil
+}
+
+// shutdownOne signals mb to stop and waits for it to exit, escalating to
+// SIGKILL if it doesn't exit within its configured grace period. It reports
+// whether SIGKILL was required.
+func (bm *BinaryManager) shutdownOne(mb *managedBinary) bool {
+	mb.mu.Lock()
+	mb.stopping = true
+	cmd := mb.cmd
+	exitCh := mb.exitCh
+	sig := mb.shutdown.Signal
+	grace := mb.shutdown.Grace
+	mb.mu.Unlock()
+
+	if cmd == nil || cmd.Process == nil || exitCh == nil {
+		return false
+	}
+
+	// Already exited (failed, or waiting out a restart backoff): nothing to
+	// signal, and nothing was force-killed.
+	select {
+	case <-exitCh:
+		return false
+	default:
+	}
+
+	pid := cmd.Process.Pid
+	log.Printf("Sending %v to %s (PID %d)", sig, mb.name, pid)
+	if err := signalProcessGroup(pid, sig); err != nil {
+		log.Printf("Failed to signal %s, killing directly: %v", mb.name, err)
+		_ = cmd.Process.Kill()
+		<-exitCh
+		return true
+	}
+
+	select {
+	case <-exitCh:
+		return false
+	case <-time.After(grace):
+		log.Printf("%s did not exit within %s, sending SIGKILL", mb.name, grace)
+		if err := signalProcessGroup(pid, syscall.SIGKILL); err != nil {
+			_ = cmd.Process.Kill()
 		}
+		<-exitCh
+		return true
 	}
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// manifestFileName is the sidecar file recording the hash, size and mode of
+// every extracted binary. It is written after each extraction but not read
+// when deciding whether to re-extract: that decision re-hashes the on-disk
+// file against the embedded binary's hash.
+const manifestFileName = "manifest.json"
+
+// manifestEntry records the expected identity of an extracted binary.
+type manifestEntry struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	Mode   uint32 `json:"mode"`
+}
+
+type manifest map[string]manifestEntry
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func sha256File(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return sha256Hex(data), nil
+}
+
+// hashEmbeddedBinaries walks bin/* in the embedded filesystem once and
+// returns the SHA-256 of every binary it contains, keyed by name.
+func hashEmbeddedBinaries(binaries fs.FS) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := fs.WalkDir(binaries, "bin", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(binaries, path)
+		if err != nil {
+			return err
+		}
+		hashes[d.Name()] = sha256Hex(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash embedded binaries: %w", err)
+	}
+	return hashes, nil
+}
+
+// readManifest loads the extraction manifest for cacheDir, returning an
+// empty manifest if it doesn't exist or can't be parsed.
+func readManifest(cacheDir string) manifest {
+	data, err := os.ReadFile(filepath.Join(cacheDir, manifestFileName))
+	if err != nil {
+		return manifest{}
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return manifest{}
+	}
+	return m
+}
+
+// writeManifest atomically persists the extraction manifest for cacheDir.
+func writeManifest(cacheDir string, m manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(cacheDir, manifestFileName), data, 0644)
+}
+
+// writeFileAtomic writes data to path by first writing to a sibling .tmp
+// file and renaming it into place, so a process crash mid-write can never
+// leave a half-written executable behind.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LogLine is a single line captured from a managed binary's stdout or
+// stderr, tagged with its originating stream and position.
+type LogLine struct {
+	Stream    string // "stdout" | "stderr"
+	Timestamp time.Time
+	Line      int
+	Text      string
+}
+
+// LogSettings controls how managed binaries' output is captured.
+type LogSettings struct {
+	MaxBytes int    // ring buffer size per binary, default 256KiB
+	Dir      string // directory for rotated log files; "" disables file logging
+	MaxFiles int    // number of rotated files to keep
+	MaxSize  int64  // size at which a log file is rotated; 0 disables size-based rotation
+}
+
+// LogCapture wraps a managed binary's stdout/stderr in a fixed-size ring
+// buffer of recent lines, optionally mirrored to a rotating log file, and
+// fans new lines out to subscribers.
+type LogCapture struct {
+	name     string
+	maxBytes int
+
+	mu         sync.Mutex
+	lines      []LogLine
+	totalBytes int
+	nextLine   int
+	subs       map[chan LogLine]struct{}
+	file       *rotatingFile
+}
+
+// newLogCapture creates a LogCapture for name. If settings.Dir is set, lines
+// are also mirrored to a rotating log file under that directory.
+func newLogCapture(name string, settings LogSettings) (*LogCapture, error) {
+	maxBytes := settings.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = 256 * 1024
+	}
+
+	lc := &LogCapture{
+		name:     name,
+		maxBytes: maxBytes,
+		subs:     make(map[chan LogLine]struct{}),
+	}
+
+	if settings.Dir != "" {
+		rf, err := newRotatingFile(settings.Dir, name, settings.MaxSize, settings.MaxFiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file for %s: %w", name, err)
+		}
+		lc.file = rf
+	}
+
+	return lc, nil
+}
+
+// Pipe returns the write end of an os.Pipe wired to capture everything
+// written to it as lines tagged with streamName, mirroring each line to
+// mirror (typically the wachat process's own stdout/stderr) as it arrives.
+//
+// The returned *os.File is meant to be handed to exec.Cmd.Stdout/Stderr
+// directly, rather than wrapped in an io.MultiWriter: os/exec only creates
+// (and later closes, in Wait) an internal pipe when Stdout/Stderr is not
+// already an *os.File. Since the SIGCHLD reaper (see reaper_unix.go) never
+// calls cmd.Wait, an internal pipe's parent-side fd would never be closed
+// and would leak on every launch and restart. Passing our own *os.File
+// sidesteps that: the caller closes the returned write end right after
+// Start(), and the read goroutine below exits (closing its end in turn)
+// once the child's copy of the write end closes on process exit.
+func (lc *LogCapture) Pipe(streamName string, mirror io.Writer) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer r.Close()
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 4096), 1024*1024)
+		for scanner.Scan() {
+			text := scanner.Text()
+			if mirror != nil {
+				fmt.Fprintln(mirror, text)
+			}
+			lc.append(streamName, text)
+		}
+	}()
+
+	return w, nil
+}
+
+func (lc *LogCapture) append(stream, text string) {
+	lc.mu.Lock()
+	line := LogLine{Stream: stream, Timestamp: time.Now(), Line: lc.nextLine, Text: text}
+	lc.nextLine++
+
+	lc.lines = append(lc.lines, line)
+	lc.totalBytes += len(text)
+	for lc.totalBytes > lc.maxBytes && len(lc.lines) > 1 {
+		lc.totalBytes -= len(lc.lines[0].Text)
+		lc.lines = lc.lines[1:]
+	}
+
+	subs := make([]chan LogLine, 0, len(lc.subs))
+	for ch := range lc.subs {
+		subs = append(subs, ch)
+	}
+	lc.mu.Unlock()
+
+	if lc.file != nil {
+		lc.file.writeLine(line)
+	}
+	for _, ch := range subs {
+		select {
+		case ch <- line:
+		default: // drop if the subscriber isn't keeping up
+		}
+	}
+}
+
+// tail returns the last n captured lines, or all of them if n <= 0 or
+// greater than the number captured.
+func (lc *LogCapture) tail(n int) []LogLine {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if n <= 0 || n > len(lc.lines) {
+		n = len(lc.lines)
+	}
+	out := make([]LogLine, n)
+	copy(out, lc.lines[len(lc.lines)-n:])
+	return out
+}
+
+// subscribe registers a new channel that receives every line appended from
+// now on. The channel is buffered; slow subscribers drop lines rather than
+// block the binary's output.
+func (lc *LogCapture) subscribe() <-chan LogLine {
+	ch := make(chan LogLine, 256)
+	lc.mu.Lock()
+	lc.subs[ch] = struct{}{}
+	lc.mu.Unlock()
+	return ch
+}
+
+// close releases the underlying log file, if any.
+func (lc *LogCapture) close() {
+	if lc.file != nil {
+		lc.file.close()
+	}
+}
+
+// rotatingFile is a simple size-based rotating log file writer.
+type rotatingFile struct {
+	mu       sync.Mutex
+	dir      string
+	name     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+func newRotatingFile(dir, name string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if maxFiles <= 0 {
+		maxFiles = 5
+	}
+	rf := &rotatingFile{dir: dir, name: name, maxSize: maxSize, maxFiles: maxFiles}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) path() string {
+	return filepath.Join(rf.dir, rf.name+".log")
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.f = f
+	rf.size = info.Size()
+	return nil
+}
+
+func (rf *rotatingFile) writeLine(line LogLine) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.f == nil {
+		return
+	}
+
+	text := fmt.Sprintf("%s [%s] %s\n", line.Timestamp.Format(time.RFC3339Nano), line.Stream, line.Text)
+	n, err := rf.f.WriteString(text)
+	if err != nil {
+		return
+	}
+	rf.size += int64(n)
+
+	if rf.maxSize > 0 && rf.size >= rf.maxSize {
+		rf.rotate()
+	}
+}
+
+// rotate closes the current file, shifts <name>.log.N -> <name>.log.N+1
+// (dropping anything past maxFiles), and opens a fresh file.
+func (rf *rotatingFile) rotate() {
+	if rf.f != nil {
+		rf.f.Close()
+		rf.f = nil
+	}
+
+	for i := rf.maxFiles - 1; i >= 1; i-- {
+		oldPath := fmt.Sprintf("%s.%d", rf.path(), i)
+		newPath := fmt.Sprintf("%s.%d", rf.path(), i+1)
+		if _, err := os.Stat(oldPath); err != nil {
+			continue
+		}
+		if i+1 > rf.maxFiles {
+			os.Remove(oldPath)
+			continue
+		}
+		os.Rename(oldPath, newPath)
+	}
+	os.Rename(rf.path(), rf.path()+".1")
+
+	if err := rf.open(); err != nil {
+		// Best effort: logging continues to stdout/ring buffer even if the
+		// file sink is temporarily unavailable.
+		rf.f = nil
+	}
+}
+
+func (rf *rotatingFile) close() {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.f != nil {
+		rf.f.Close()
+		rf.f = nil
+	}
+}
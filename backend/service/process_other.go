@@ -0,0 +1,22 @@
+//go:build !unix
+
+package service
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup is a no-op on platforms without POSIX process groups.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalProcessGroup has no process-group concept outside Unix, so it just
+// kills the process directly.
+func signalProcessGroup(pid int, sig syscall.Signal) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package service
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func init() {
+	signalNames["SIGUSR1"] = syscall.SIGUSR1
+	signalNames["SIGUSR2"] = syscall.SIGUSR2
+}
+
+// setProcessGroup places cmd in its own process group so that a signal sent
+// to -pgid reaches the whole process tree it spawns, not just the direct
+// child.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// signalProcessGroup sends sig to the process group led by pid.
+func signalProcessGroup(pid int, sig syscall.Signal) error {
+	return syscall.Kill(-pid, sig)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"time"
+)
+
+// ProbeType names a readiness probe mechanism.
+type ProbeType string
+
+const (
+	ProbeTCP  ProbeType = "tcp"
+	ProbeHTTP ProbeType = "http"
+	ProbeExec ProbeType = "exec"
+)
+
+// ReadinessProbe declares how to decide a binary is ready to accept
+// dependants' traffic once it has started. A zero-value probe (empty Type)
+// means "ready as soon as the process starts".
+type ReadinessProbe struct {
+	Type             ProbeType
+	Target           string   // host:port for tcp, URL for http, command for exec
+	Args             []string // exec only
+	InitialDelay     time.Duration
+	Interval         time.Duration
+	Timeout          time.Duration
+	FailureThreshold int // consecutive failures allowed before giving up
+}
+
+// LifecyclePhase describes a point in a managed binary's startup lifecycle.
+type LifecyclePhase string
+
+const (
+	PhaseStarting  LifecyclePhase = "starting"
+	PhaseReady     LifecyclePhase = "ready"
+	PhaseUnhealthy LifecyclePhase = "unhealthy"
+	PhaseExited    LifecyclePhase = "exited"
+)
+
+// LifecycleEvent is emitted as a managed binary moves through startup, and
+// each time a supervised process exits.
+type LifecycleEvent struct {
+	Name      string
+	Phase     LifecyclePhase
+	Timestamp time.Time
+	Err       error
+}
+
+// waitReady blocks until probe passes, the probe's failure threshold is
+// exhausted, or ctx is cancelled.
+func (bm *BinaryManager) waitReady(ctx context.Context, name string, probe ReadinessProbe) error {
+	if probe.Type == "" {
+		return nil
+	}
+
+	if probe.InitialDelay > 0 {
+		select {
+		case <-time.After(probe.InitialDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	interval := probe.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	threshold := probe.FailureThreshold
+	if threshold <= 0 {
+		threshold = 3
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= threshold; attempt++ {
+		if err := runProbe(ctx, probe); err == nil {
+			return nil
+		} else {
+			lastErr = err
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("probe for %s did not succeed after %d attempts: %w", name, threshold, lastErr)
+}
+
+// runProbe executes a single readiness check of the configured type.
+func runProbe(ctx context.Context, probe ReadinessProbe) error {
+	timeout := probe.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch probe.Type {
+	case ProbeTCP:
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", probe.Target)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case ProbeHTTP:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.Target, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	case ProbeExec:
+		cmd := exec.CommandContext(ctx, probe.Target, probe.Args...)
+		return cmd.Run()
+	default:
+		return fmt.Errorf("unknown probe type %q", probe.Type)
+	}
+}
+
+// topoSort orders names so that every name appears after everything listed
+// in its dependsOn, detecting cycles. Dependencies that are not themselves
+// in names are skipped and do not appear in the result.
+func topoSort(names []string, dependsOn map[string][]string) ([]string, error) {
+	const (
+		unvisited = 0
+		visiting  = 1
+		done      = 2
+	)
+	state := make(map[string]int, len(names))
+	order := make([]string, 0, len(names))
+	known := make(map[string]bool, len(names))
+	for _, n := range names {
+		known[n] = true
+	}
+
+	var visit func(string) error
+	visit = func(n string) error {
+		switch state[n] {
+		case visiting:
+			return fmt.Errorf("dependency cycle detected at %q", n)
+		case done:
+			return nil
+		}
+		state[n] = visiting
+		for _, dep := range dependsOn[n] {
+			if !known[dep] {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[n] = done
+		order = append(order, n)
+		return nil
+	}
+
+	for _, n := range names {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import "testing"
+
+func TestTopoSortOrdersDependenciesFirst(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	dependsOn := map[string][]string{
+		"b": {"a"},
+		"c": {"b"},
+	}
+
+	order, err := topoSort(names, dependsOn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pos := make(map[string]int, len(order))
+	for i, n := range order {
+		pos[n] = i
+	}
+	if pos["a"] > pos["b"] || pos["b"] > pos["c"] {
+		t.Fatalf("dependencies not ordered before dependants: %v", order)
+	}
+}
+
+func TestTopoSortDetectsCycle(t *testing.T) {
+	names := []string{"a", "b"}
+	dependsOn := map[string][]string{
+		"a": {"b"},
+		"b": {"a"},
+	}
+
+	if _, err := topoSort(names, dependsOn); err == nil {
+		t.Fatal("expected cycle detection error, got nil")
+	}
+}
+
+func TestTopoSortIgnoresUnknownDependency(t *testing.T) {
+	names := []string{"a"}
+	dependsOn := map[string][]string{
+		"a": {"does-not-exist"},
+	}
+
+	order, err := topoSort(names, dependsOn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(order) != 1 || order[0] != "a" {
+		t.Fatalf("unexpected order: %v", order)
+	}
+}
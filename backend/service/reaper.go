@@ -0,0 +1,9 @@
+package service
+
+// ExitStatus describes how a managed binary's process exited, independent
+// of how the exit was observed (direct Wait() or a SIGCHLD reaper).
+type ExitStatus struct {
+	ExitCode int
+	Signaled bool
+	Signal   string
+}
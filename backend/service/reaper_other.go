@@ -0,0 +1,28 @@
+//go:build !unix
+
+package service
+
+import "os/exec"
+
+// startReaper is a no-op outside Unix; there's no SIGCHLD to hook a
+// package-level reaper into.
+func startReaper() {}
+
+// waitForExit falls back to exec.Cmd's own Wait, since there's no reaper to
+// register pid-keyed handlers with on this platform.
+func waitForExit(cmd *exec.Cmd) <-chan ExitStatus {
+	ch := make(chan ExitStatus, 1)
+	go func() {
+		err := cmd.Wait()
+		var status ExitStatus
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				status.ExitCode = exitErr.ExitCode()
+			} else {
+				status.ExitCode = -1
+			}
+		}
+		ch <- status
+	}()
+	return ch
+}
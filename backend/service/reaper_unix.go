@@ -0,0 +1,98 @@
+//go:build unix
+
+package service
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+var reaper = struct {
+	once     sync.Once
+	mu       sync.Mutex
+	handlers map[int]func(ExitStatus)
+}{handlers: make(map[int]func(ExitStatus))}
+
+// startReaper launches a package-level SIGCHLD handler that reaps every
+// exited child via wait4(-1, WNOHANG), including orphaned grandchildren
+// inherited when wachat runs as PID 1 in a container, and dispatches each
+// exit to whichever handler registered for that pid via registerReapHandler.
+// It is safe to call more than once; only the first call starts anything.
+func startReaper() {
+	reaper.once.Do(func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGCHLD)
+		go func() {
+			for range sigCh {
+				reapAll()
+			}
+		}()
+	})
+}
+
+// reapAll drains every exited child currently waiting to be reaped.
+func reapAll() {
+	for {
+		var ws syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &ws, syscall.WNOHANG, nil)
+		if err != nil {
+			if err != syscall.ECHILD {
+				log.Printf("reaper: wait4: %v", err)
+			}
+			return
+		}
+		if pid <= 0 {
+			return
+		}
+
+		reaper.mu.Lock()
+		handler := reaper.handlers[pid]
+		delete(reaper.handlers, pid)
+		reaper.mu.Unlock()
+
+		if handler == nil {
+			// An orphaned grandchild (or a process we never registered
+			// interest in) - it's reaped either way, just nobody to tell.
+			continue
+		}
+
+		status := ExitStatus{ExitCode: ws.ExitStatus()}
+		if ws.Signaled() {
+			status.Signaled = true
+			status.Signal = ws.Signal().String()
+		}
+		handler(status)
+	}
+}
+
+// registerReapHandler arranges for fn to be called, exactly once, with the
+// exit status of pid once the reaper observes it exit via SIGCHLD.
+func registerReapHandler(pid int, fn func(ExitStatus)) {
+	reaper.mu.Lock()
+	reaper.handlers[pid] = fn
+	reaper.mu.Unlock()
+}
+
+// unregisterReapHandler cancels a pending reap handler, e.g. because the
+// caller gave up waiting on it.
+func unregisterReapHandler(pid int) {
+	reaper.mu.Lock()
+	delete(reaper.handlers, pid)
+	reaper.mu.Unlock()
+}
+
+// waitForExit returns a channel that receives cmd's exit status once the
+// package-level reaper observes its process exit. Unlike exec.Cmd.Wait,
+// this never calls wait4 itself, so it can't race the reaper for the same
+// pid.
+func waitForExit(cmd *exec.Cmd) <-chan ExitStatus {
+	ch := make(chan ExitStatus, 1)
+	registerReapHandler(cmd.Process.Pid, func(status ExitStatus) {
+		ch <- status
+	})
+	return ch
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Restart modes for RestartPolicy.Mode.
+const (
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+	RestartNever     = "never"
+)
+
+// RestartPolicy controls whether and how a supervised binary is restarted
+// after it exits.
+type RestartPolicy struct {
+	Mode        string        // on-failure | always | never
+	MaxAttempts int           // 0 means unlimited
+	Delay       time.Duration // base delay before the first restart attempt
+	MaxDelay    time.Duration // backoff ceiling
+	ResetAfter  time.Duration // uptime required before the attempt counter resets
+}
+
+// defaultRestartPolicy is used when a binary has no restart policy configured.
+func defaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		Mode:       RestartNever,
+		Delay:      500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+		ResetAfter: 5 * time.Minute,
+	}
+}
+
+// shouldRestart reports whether the policy allows another restart attempt
+// given the exit was clean (exitedCleanly) and the number of consecutive
+// failures already observed.
+func (p RestartPolicy) shouldRestart(exitedCleanly bool, attempt int) bool {
+	switch p.Mode {
+	case RestartAlways:
+		// fall through to attempt check
+	case RestartOnFailure:
+		if exitedCleanly {
+			return false
+		}
+	default: // RestartNever or unrecognized
+		return false
+	}
+
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return false
+	}
+	return true
+}
+
+// backoff computes the delay before restart attempt number `attempt`
+// (1-indexed), applying exponential growth capped at MaxDelay with full
+// jitter.
+func (p RestartPolicy) backoff(attempt int) time.Duration {
+	delay := p.Delay
+	if delay <= 0 {
+		delay = defaultRestartPolicy().Delay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRestartPolicy().MaxDelay
+	}
+
+	// delay * 2^(attempt-1), capped
+	capped := delay
+	for i := 1; i < attempt; i++ {
+		capped *= 2
+		if capped >= maxDelay {
+			capped = maxDelay
+			break
+		}
+	}
+	if capped > maxDelay {
+		capped = maxDelay
+	}
+
+	// full jitter: random value in [0, capped]
+	if capped <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
@@ -0,0 +1,46 @@
+package service
+
+import "testing"
+
+func TestShouldRestartMaxAttempts(t *testing.T) {
+	p := RestartPolicy{Mode: RestartOnFailure, MaxAttempts: 1}
+
+	if !p.shouldRestart(false, 1) {
+		t.Fatalf("max_attempts: 1 should allow the first restart attempt")
+	}
+	if p.shouldRestart(false, 2) {
+		t.Fatalf("max_attempts: 1 should not allow a second restart attempt")
+	}
+}
+
+func TestShouldRestartModes(t *testing.T) {
+	cases := []struct {
+		mode          string
+		exitedCleanly bool
+		want          bool
+	}{
+		{RestartNever, true, false},
+		{RestartNever, false, false},
+		{RestartOnFailure, true, false},
+		{RestartOnFailure, false, true},
+		{RestartAlways, true, true},
+		{RestartAlways, false, true},
+	}
+
+	for _, c := range cases {
+		p := RestartPolicy{Mode: c.mode}
+		if got := p.shouldRestart(c.exitedCleanly, 1); got != c.want {
+			t.Errorf("mode=%s exitedCleanly=%v: got %v, want %v", c.mode, c.exitedCleanly, got, c.want)
+		}
+	}
+}
+
+func TestBackoffCapsAtMaxDelay(t *testing.T) {
+	p := RestartPolicy{Delay: 1, MaxDelay: 100}
+
+	for attempt := 1; attempt <= 20; attempt++ {
+		if d := p.backoff(attempt); d > p.MaxDelay {
+			t.Fatalf("backoff(%d) = %d, want <= max delay %d", attempt, d, p.MaxDelay)
+		}
+	}
+}
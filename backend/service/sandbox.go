@@ -0,0 +1,34 @@
+package service
+
+// SandboxMount is a read-only (or read-write) bind mount exposed to a
+// sandboxed binary.
+type SandboxMount struct {
+	Src string
+	Dst string
+	RO  bool
+}
+
+// SandboxSpec declares optional resource limits and isolation to apply to a
+// managed binary before it execs. It is accepted on every platform, but
+// only enforced on Linux (see sandbox_linux.go); other platforms fall back
+// to a no-op so BinaryManager stays cross-platform (sandbox_other.go).
+type SandboxSpec struct {
+	Enabled bool
+
+	MemoryMax int64  // bytes; cgroup v2 memory.max
+	CPUMax    string // cgroup v2 cpu.max, e.g. "50000 100000" for a quota/period pair
+	PidsMax   int64  // cgroup v2 pids.max
+
+	NoNewPrivs bool
+	PrivateTmp bool
+	DropCaps   []string
+	Mounts     []SandboxMount
+}
+
+// SandboxStatus reports the sandbox limits actually applied to a binary, for
+// display alongside BinaryStatus.
+type SandboxStatus struct {
+	Sandboxed bool
+	MemoryMax int64
+	PidsMax   int64
+}
@@ -0,0 +1,226 @@
+//go:build linux
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// cgroupRoot is the parent cgroup v2 slice all managed binaries are placed
+// under. It must already exist and be delegated to wachat's uid (systemd
+// does this automatically for a "wachat.slice" unit).
+const cgroupRoot = "/sys/fs/cgroup/wachat.slice"
+
+// sandboxInitEnv, when set in a child's environment, tells a re-exec'd copy
+// of wachat to act as the sandbox init for the target named in its value:
+// set up the mount namespace and capabilities, then exec the target in
+// place. See SandboxMain, which must be called first in main().
+const sandboxInitEnv = "WACHAT_SANDBOX_TARGET"
+
+var capNumbers = map[string]uintptr{
+	"CAP_CHOWN":            0,
+	"CAP_DAC_OVERRIDE":     1,
+	"CAP_SETUID":           7,
+	"CAP_SETGID":           6,
+	"CAP_NET_BIND_SERVICE": 10,
+	"CAP_NET_RAW":          13,
+	"CAP_SYS_CHROOT":       18,
+	"CAP_SYS_PTRACE":       19,
+	"CAP_SYS_ADMIN":        21,
+	"CAP_NET_ADMIN":        12,
+}
+
+// applySandbox wires cmd to run under the limits described by spec: cgroup
+// v2 limits written to /sys/fs/cgroup/wachat.slice/<name>/* (joined via
+// joinCgroup once the process has started), and, when no_new_privs, a
+// private /tmp, bind mounts or dropped capabilities are requested, a
+// re-exec through wachat itself so they can be applied via prctl/mount
+// syscalls before the real target binary takes over. NoNewPrivs is applied
+// this way because syscall.SysProcAttr has no field for it.
+func applySandbox(cmd *exec.Cmd, name string, spec SandboxSpec) (SandboxStatus, error) {
+	if !spec.Enabled {
+		return SandboxStatus{}, nil
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	needsNamespace := spec.PrivateTmp || len(spec.Mounts) > 0
+	if needsNamespace {
+		cmd.SysProcAttr.Unshareflags = syscall.CLONE_NEWNS
+	}
+
+	if needsNamespace || spec.NoNewPrivs || len(spec.DropCaps) > 0 {
+		self, err := os.Executable()
+		if err != nil {
+			return SandboxStatus{}, fmt.Errorf("failed to resolve self for sandbox re-exec: %w", err)
+		}
+		target := cmd.Path
+		cmd.Path = self
+		cmd.Args = append([]string{self}, cmd.Args[1:]...)
+		cmd.Env = append(cmd.Environ(), sandboxInitEnv+"="+target+"|"+encodeSandboxSpec(spec))
+	}
+
+	cgroupDir := filepath.Join(cgroupRoot, name)
+	if err := os.MkdirAll(cgroupDir, 0755); err != nil {
+		return SandboxStatus{}, fmt.Errorf("failed to create cgroup for %s: %w", name, err)
+	}
+	if spec.MemoryMax > 0 {
+		if err := writeCgroupFile(cgroupDir, "memory.max", strconv.FormatInt(spec.MemoryMax, 10)); err != nil {
+			return SandboxStatus{}, err
+		}
+	}
+	if spec.PidsMax > 0 {
+		if err := writeCgroupFile(cgroupDir, "pids.max", strconv.FormatInt(spec.PidsMax, 10)); err != nil {
+			return SandboxStatus{}, err
+		}
+	}
+	if spec.CPUMax != "" {
+		if err := writeCgroupFile(cgroupDir, "cpu.max", spec.CPUMax); err != nil {
+			return SandboxStatus{}, err
+		}
+	}
+
+	return SandboxStatus{Sandboxed: true, MemoryMax: spec.MemoryMax, PidsMax: spec.PidsMax}, nil
+}
+
+// joinCgroup adds pid to name's cgroup. Must be called right after Start(),
+// since cgroup v2 only accepts processes, not threads, into cgroup.procs.
+func joinCgroup(name string, pid int) error {
+	return writeCgroupFile(filepath.Join(cgroupRoot, name), "cgroup.procs", strconv.Itoa(pid))
+}
+
+func writeCgroupFile(dir, file, value string) error {
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, []byte(value), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// encodeSandboxSpec serializes the parts of spec the re-exec'd init needs
+// into a single environment-variable-safe string.
+func encodeSandboxSpec(spec SandboxSpec) string {
+	var mounts []string
+	for _, m := range spec.Mounts {
+		ro := "rw"
+		if m.RO {
+			ro = "ro"
+		}
+		mounts = append(mounts, m.Src+":"+m.Dst+":"+ro)
+	}
+
+	fields := []string{
+		"tmp=" + strconv.FormatBool(spec.PrivateTmp),
+		"nnp=" + strconv.FormatBool(spec.NoNewPrivs),
+		"caps=" + strings.Join(spec.DropCaps, ","),
+		"mounts=" + strings.Join(mounts, ","),
+	}
+	return strings.Join(fields, ";")
+}
+
+// decodeSandboxSpec parses the string produced by encodeSandboxSpec.
+func decodeSandboxSpec(s string) (privateTmp, noNewPrivs bool, dropCaps []string, mounts []SandboxMount) {
+	for _, field := range strings.Split(s, ";") {
+		k, v, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "tmp":
+			privateTmp = v == "true"
+		case "nnp":
+			noNewPrivs = v == "true"
+		case "caps":
+			if v != "" {
+				dropCaps = strings.Split(v, ",")
+			}
+		case "mounts":
+			for _, m := range strings.Split(v, ",") {
+				if m == "" {
+					continue
+				}
+				parts := strings.SplitN(m, ":", 3)
+				if len(parts) != 3 {
+					continue
+				}
+				mounts = append(mounts, SandboxMount{Src: parts[0], Dst: parts[1], RO: parts[2] == "ro"})
+			}
+		}
+	}
+	return
+}
+
+// SandboxMain must be called first in main(), before any other
+// initialization. If the process was re-exec'd by applySandbox to act as a
+// sandbox init, it sets up the mount namespace and drops capabilities, then
+// execs the real target in place and never returns. Otherwise it returns
+// immediately so the normal wachat startup proceeds.
+func SandboxMain() {
+	raw, ok := os.LookupEnv(sandboxInitEnv)
+	if !ok {
+		return
+	}
+	target, specStr, ok := strings.Cut(raw, "|")
+	if !ok {
+		fmt.Fprintf(os.Stderr, "sandbox init: malformed %s\n", sandboxInitEnv)
+		os.Exit(1)
+	}
+	privateTmp, noNewPrivs, dropCaps, mounts := decodeSandboxSpec(specStr)
+
+	if noNewPrivs {
+		const prSetNoNewPrivs = 38
+		if _, _, errno := syscall.Syscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); errno != 0 {
+			fmt.Fprintf(os.Stderr, "sandbox init: set no_new_privs: %v\n", errno)
+			os.Exit(1)
+		}
+	}
+
+	if privateTmp {
+		if err := syscall.Mount("tmpfs", "/tmp", "tmpfs", 0, "mode=1777"); err != nil {
+			fmt.Fprintf(os.Stderr, "sandbox init: mount private /tmp: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	for _, m := range mounts {
+		flags := uintptr(syscall.MS_BIND)
+		if err := syscall.Mount(m.Src, m.Dst, "", flags, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "sandbox init: bind mount %s -> %s: %v\n", m.Src, m.Dst, err)
+			os.Exit(1)
+		}
+		if m.RO {
+			flags |= syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY
+			if err := syscall.Mount(m.Src, m.Dst, "", flags, ""); err != nil {
+				fmt.Fprintf(os.Stderr, "sandbox init: remount %s read-only: %v\n", m.Dst, err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	for _, name := range dropCaps {
+		capNum, ok := capNumbers[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "sandbox init: unknown capability %q, skipping\n", name)
+			continue
+		}
+		const prCapbsetDrop = 24
+		if _, _, errno := syscall.Syscall(syscall.SYS_PRCTL, prCapbsetDrop, capNum, 0); errno != 0 {
+			fmt.Fprintf(os.Stderr, "sandbox init: drop %s: %v\n", name, errno)
+			os.Exit(1)
+		}
+	}
+
+	argv := append([]string{target}, os.Args[1:]...)
+	env := os.Environ()
+	if err := syscall.Exec(target, argv, env); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox init: exec %s: %v\n", target, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package service
+
+import "os/exec"
+
+// applySandbox is a no-op outside Linux: cgroups and mount-namespace
+// isolation have no equivalent here, so a configured sandbox block is
+// accepted but never enforced.
+func applySandbox(cmd *exec.Cmd, name string, spec SandboxSpec) (SandboxStatus, error) {
+	return SandboxStatus{}, nil
+}
+
+// joinCgroup is a no-op outside Linux.
+func joinCgroup(name string, pid int) error {
+	return nil
+}
+
+// SandboxMain is a no-op outside Linux; see sandbox_linux.go.
+func SandboxMain() {}
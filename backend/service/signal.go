@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ShutdownPolicy controls how a managed binary is asked to stop: which
+// signal it receives first and how long it is given to exit cleanly before
+// being force-killed with SIGKILL.
+type ShutdownPolicy struct {
+	Signal syscall.Signal
+	Grace  time.Duration
+}
+
+// defaultShutdownPolicy is used when a binary has no shutdown policy configured.
+func defaultShutdownPolicy() ShutdownPolicy {
+	return ShutdownPolicy{Signal: syscall.SIGTERM, Grace: 10 * time.Second}
+}
+
+// signalNames maps the signal names accepted in BinariesConfig to their
+// syscall.Signal value. Unix-only signals are added to this map by
+// process_unix.go's init().
+var signalNames = map[string]syscall.Signal{
+	"SIGTERM": syscall.SIGTERM,
+	"SIGINT":  syscall.SIGINT,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGKILL": syscall.SIGKILL,
+}
+
+// ParseSignal resolves a signal name such as "SIGTERM" or "SIGINT" to a
+// syscall.Signal. An empty name resolves to SIGTERM.
+func ParseSignal(name string) (syscall.Signal, error) {
+	if name == "" {
+		return syscall.SIGTERM, nil
+	}
+	sig, ok := signalNames[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
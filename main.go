@@ -8,12 +8,17 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 	"github.com/wangle201210/wachat/backend/config"
+	"github.com/wangle201210/wachat/backend/service"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	// If this process is a sandbox re-exec, finish setting up its mount
+	// namespace and hand off to the real target; it never returns.
+	service.SandboxMain()
+
 	// Load configuration from yaml
 	cfg, err := config.Load()
 	if err != nil {